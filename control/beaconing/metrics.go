@@ -0,0 +1,43 @@
+// Copyright 2024 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/scionproto/scion/pkg/metrics"
+)
+
+const (
+	originationBackoffN    = "beaconing_origination_backoff"
+	originationBackoffHelp = "Set to 1 for every egress interface currently demoted to a " +
+		"slower origination rotation by adaptive pacing backoff, and 0 otherwise."
+)
+
+// NewOriginationBackoffGauge registers and returns the
+// beaconing_origination_backoff gauge, labeled by egress_interface. The
+// control service must assign the result to Originator.OriginationBackoff
+// before the originator task is started; setBackoffGauge silently does
+// nothing as long as that field is nil.
+func NewOriginationBackoffGauge() metrics.Gauge {
+	return metrics.NewPromGauge(promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: originationBackoffN,
+			Help: originationBackoffHelp,
+		},
+		[]string{"egress_interface"},
+	))
+}
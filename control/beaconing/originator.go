@@ -33,10 +33,24 @@ import (
 	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/private/periodic"
 	"github.com/scionproto/scion/private/procperf"
+	"github.com/scionproto/scion/private/tracing"
 )
 
 var _ periodic.Task = (*Originator)(nil)
 
+const (
+	// defaultEWMAAlpha is the smoothing factor used for an interface's
+	// origination latency EWMA when PacingConfig.EWMAAlpha is unset.
+	defaultEWMAAlpha = 0.3
+	// defaultBackoffMultiplier is the factor applied to Originator.TickPeriod
+	// to obtain the demotion threshold when PacingConfig.BackoffMultiplier is
+	// unset.
+	defaultBackoffMultiplier = 2.0
+	// defaultBackoffSkipTicks is the number of ticks an interface is demoted
+	// for when PacingConfig.BackoffSkipTicks is unset.
+	defaultBackoffSkipTicks = 3
+)
+
 // SenderFactory can be used to create a new beacon sender.
 type SenderFactory interface {
 	// NewSender creates a new beacon sender to the specified ISD-AS over the given egress
@@ -69,9 +83,158 @@ type Originator struct {
 	OriginationInterfaces func() []*ifstate.Interface
 
 	Originated metrics.Counter
+	// OriginationBackoff is set to 1, labeled by egress_interface, for every
+	// interface currently demoted to a slower origination rotation, and 0
+	// otherwise. It must be set to NewOriginationBackoffGauge() before the
+	// originator task is started, or setBackoffGauge silently does nothing.
+	OriginationBackoff metrics.Gauge
+
+	// TickPeriod is the nominal propagation period. It is used to turn
+	// Pacing.BackoffMultiplier into an absolute latency threshold. If zero,
+	// adaptive demotion is disabled.
+	TickPeriod time.Duration
+	// Pacing holds the operator-tunable knobs for the worker pool and the
+	// adaptive per-interface deadline/backoff, as read from the
+	// control-service config (see PacingConfig). The zero value gives
+	// unbounded concurrency and the default EWMA alpha, backoff multiplier
+	// and skip ticks.
+	Pacing PacingConfig
 
 	// Tick is mutable.
 	Tick Tick
+
+	// states tracks the per-interface origination latency EWMA and backoff
+	// state across ticks; keyed by egress interface ID.
+	states sync.Map
+}
+
+// originationState is the adaptive pacing state kept for a single egress
+// interface across ticks.
+type originationState struct {
+	mu            sync.Mutex
+	ewma          time.Duration
+	skipRemaining int
+}
+
+// update folds sample into the EWMA and returns the new value.
+func (st *originationState) update(alpha float64, sample time.Duration) time.Duration {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.ewma == 0 {
+		st.ewma = sample
+	} else {
+		st.ewma = time.Duration(alpha*float64(sample) + (1-alpha)*float64(st.ewma))
+	}
+	return st.ewma
+}
+
+// currentEWMA returns the smoothed latency, or zero if no sample was
+// recorded yet.
+func (st *originationState) currentEWMA() time.Duration {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.ewma
+}
+
+// demoteFor sets the number of ticks to skip for this interface.
+func (st *originationState) demoteFor(skipTicks int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.skipRemaining = skipTicks
+}
+
+// consumeSkip reports whether this tick should be skipped for this
+// interface, decrementing the remaining skip count if so.
+func (st *originationState) consumeSkip() bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.skipRemaining <= 0 {
+		return false
+	}
+	st.skipRemaining--
+	return true
+}
+
+// stateFor returns the origination state for ifID, creating it on first use.
+func (o *Originator) stateFor(ifID uint16) *originationState {
+	v, _ := o.states.LoadOrStore(ifID, &originationState{})
+	return v.(*originationState)
+}
+
+func (o *Originator) ewmaAlpha() float64 {
+	if o.Pacing.EWMAAlpha <= 0 || o.Pacing.EWMAAlpha > 1 {
+		return defaultEWMAAlpha
+	}
+	return o.Pacing.EWMAAlpha
+}
+
+func (o *Originator) backoffMultiplier() float64 {
+	if o.Pacing.BackoffMultiplier <= 0 {
+		return defaultBackoffMultiplier
+	}
+	return o.Pacing.BackoffMultiplier
+}
+
+func (o *Originator) backoffSkipTicks() int {
+	if o.Pacing.BackoffSkipTicks <= 0 {
+		return defaultBackoffSkipTicks
+	}
+	return o.Pacing.BackoffSkipTicks
+}
+
+// originationDeadline derives the per-interface deadline for NewSender from
+// the interface's smoothed create+newSender+send latency, falling back to
+// defaultNewSenderTimeout until a first sample is recorded. Beacon creation
+// and Send are not bounded by this deadline.
+func (o *Originator) originationDeadline(ifID uint16) time.Duration {
+	ewma := o.stateFor(ifID).currentEWMA()
+	if ewma == 0 {
+		return defaultNewSenderTimeout
+	}
+	if deadline := 2 * ewma; deadline > defaultNewSenderTimeout {
+		return deadline
+	}
+	return defaultNewSenderTimeout
+}
+
+// recordOriginationLatency folds sample into ifID's latency EWMA and demotes
+// or recovers the interface depending on whether the smoothed latency
+// exceeds BackoffMultiplier*TickPeriod.
+func (o *Originator) recordOriginationLatency(ifID uint16, sample time.Duration) {
+	st := o.stateFor(ifID)
+	ewma := st.update(o.ewmaAlpha(), sample)
+
+	if o.TickPeriod <= 0 {
+		return
+	}
+	threshold := time.Duration(o.backoffMultiplier() * float64(o.TickPeriod))
+	if ewma > threshold {
+		st.demoteFor(o.backoffSkipTicks())
+		o.setBackoffGauge(ifID, true)
+		return
+	}
+	st.demoteFor(0)
+	o.setBackoffGauge(ifID, false)
+}
+
+func (o *Originator) setBackoffGauge(ifID uint16, demoted bool) {
+	if o.OriginationBackoff == nil {
+		return
+	}
+	value := 0.0
+	if demoted {
+		value = 1.0
+	}
+	o.OriginationBackoff.With("egress_interface", strconv.Itoa(int(ifID))).Set(value)
+}
+
+// workerPoolSize returns how many of n due interfaces may be originated on
+// concurrently.
+func (o *Originator) workerPoolSize(n int) int {
+	if o.Pacing.MaxConcurrentOriginations <= 0 || o.Pacing.MaxConcurrentOriginations > n {
+		return n
+	}
+	return o.Pacing.MaxConcurrentOriginations
 }
 
 // Name returns the tasks name.
@@ -102,9 +265,27 @@ func (o *Originator) originateBeacons(ctx context.Context) {
 	logger := withSilent(ctx, silent)
 
 	s := newSummary()
-	var wg sync.WaitGroup
-	wg.Add(len(intfs))
+
+	// Interfaces currently demoted for being consistently slow skip this
+	// tick entirely, so a single slow neighbor can't stall or crowd out the
+	// rest of the rotation.
+	due := intfs[:0:0]
 	for _, intf := range intfs {
+		if o.stateFor(intf.TopoInfo().ID).consumeSkip() {
+			continue
+		}
+		due = append(due, intf)
+	}
+	if len(due) == 0 {
+		o.logSummary(logger, s)
+		return
+	}
+
+	sem := make(chan struct{}, o.workerPoolSize(len(due)))
+	var wg sync.WaitGroup
+	wg.Add(len(due))
+	for _, intf := range due {
+		sem <- struct{}{}
 		b := beaconOriginator{
 			Originator: o,
 			intf:       intf,
@@ -114,6 +295,7 @@ func (o *Originator) originateBeacons(ctx context.Context) {
 		go func() {
 			defer log.HandlePanic()
 			defer wg.Done()
+			defer func() { <-sem }()
 
 			if err := b.originateBeacon(ctx); err != nil {
 				logger.Info("Unable to originate on interface",
@@ -157,9 +339,18 @@ type beaconOriginator struct {
 	summary   *summary
 }
 
-// originateBeacon originates a beacon on the given ifID.
-func (o *beaconOriginator) originateBeacon(ctx context.Context) error {
-	pp := procperf.GetNew(procperf.Originated, "") // Add beacon ID after creation
+// originateBeacon originates a beacon on the given ifID. Whether it succeeds
+// or fails, the elapsed time is fed into the interface's origination latency
+// EWMA, so that an unresponsive neighbor that only ever fails with a
+// deadline error still gets demoted; see recordOriginationLatency.
+func (o *beaconOriginator) originateBeacon(ctx context.Context) (err error) {
+	ifID := o.intf.TopoInfo().ID
+	attemptStart := time.Now()
+	defer func() {
+		o.Originator.recordOriginationLatency(ifID, time.Since(attemptStart))
+	}()
+
+	ctx, pp := procperf.GetNew(ctx, procperf.Originated, "") // Add beacon ID after creation
 	timeCreateS := time.Now()
 	labels := originatorLabels{intf: o.intf}
 	topoInfo := o.intf.TopoInfo()
@@ -173,9 +364,22 @@ func (o *beaconOriginator) originateBeacon(ctx context.Context) error {
 	bcnId := procperf.GetFullId(beacon.GetLoggingID(), beacon.Info.SegmentID)
 	pp.SetID(bcnId)
 	pp.SetNextID(bcnId)
+	// Scope note: this records the traceparent as a span attribute, which
+	// lets an exporter correlate the origination span with whatever else
+	// carries the same traceparent by ID. It deliberately stops there and
+	// does NOT put the traceparent on the wire, because that requires an
+	// extension field on seg.PathSegment (package
+	// github.com/scionproto/scion/pkg/segment), which is out of scope for
+	// this change -- it is not touched anywhere else in this series and
+	// isn't present in this part of the tree. Continuing a trace across ASes
+	// from the beacon itself is therefore not implemented; it needs a
+	// follow-up that adds the wire field and threads
+	// tracing.InjectString/ExtractString through it on the sending and
+	// receiving sides.
+	pp.SetTraceparent(tracing.InjectString(ctx))
 	defer pp.Write()
 	timeSenderS := time.Now()
-	senderCtx, cancelF := context.WithTimeout(ctx, defaultNewSenderTimeout)
+	senderCtx, cancelF := context.WithTimeout(ctx, o.originationDeadline(o.intf.TopoInfo().ID))
 	defer cancelF()
 
 	sender, err := o.SenderFactory.NewSender(
@@ -0,0 +1,43 @@
+// Copyright 2024 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+// PacingConfig configures the Originator's bounded worker pool and adaptive
+// per-interface deadline/backoff. It is meant to be embedded in the
+// control-service configuration (e.g. as the `[beaconing.origination_pacing]`
+// TOML table) and copied into Originator.Pacing before the originator task is
+// started, so operators can tune it without recompiling.
+//
+// The zero value gives unbounded concurrency and the default EWMA alpha,
+// backoff multiplier and skip ticks; it does not disable demotion, which is
+// only disabled while Originator.TickPeriod is zero.
+type PacingConfig struct {
+	// MaxConcurrentOriginations bounds the number of interfaces originated on
+	// concurrently. If zero or negative, all due interfaces are originated on
+	// in parallel.
+	MaxConcurrentOriginations int `toml:"max_concurrent_originations,omitempty"`
+	// EWMAAlpha is the smoothing factor, in (0, 1], for the per-interface
+	// origination latency EWMA. If unset, defaultEWMAAlpha is used.
+	EWMAAlpha float64 `toml:"ewma_alpha,omitempty"`
+	// BackoffMultiplier is the factor applied to Originator.TickPeriod to
+	// obtain the latency an interface's smoothed origination time must
+	// exceed before it is demoted. If unset, defaultBackoffMultiplier is
+	// used.
+	BackoffMultiplier float64 `toml:"backoff_multiplier,omitempty"`
+	// BackoffSkipTicks is the number of ticks a demoted interface is skipped
+	// for before it is tried again. If unset, defaultBackoffSkipTicks is
+	// used.
+	BackoffSkipTicks int `toml:"backoff_skip_ticks,omitempty"`
+}
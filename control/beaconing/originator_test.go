@@ -0,0 +1,108 @@
+// Copyright 2024 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// NOTE: Originator.originateBeacons also depends on ifstate.Interface and
+// Extender, neither of which live in this part of the tree, so it can't be
+// driven end to end here. These tests instead cover the pacing logic
+// (originationState, PacingConfig defaults, workerPoolSize,
+// originationDeadline and recordOriginationLatency) directly, since that is
+// exactly the new behavior this change adds.
+
+func TestPacingConfigDefaults(t *testing.T) {
+	var o Originator
+	assert.Equal(t, defaultEWMAAlpha, o.ewmaAlpha())
+	assert.Equal(t, defaultBackoffMultiplier, o.backoffMultiplier())
+	assert.Equal(t, defaultBackoffSkipTicks, o.backoffSkipTicks())
+
+	o.Pacing = PacingConfig{EWMAAlpha: 0.5, BackoffMultiplier: 3, BackoffSkipTicks: 7}
+	assert.Equal(t, 0.5, o.ewmaAlpha())
+	assert.Equal(t, 3.0, o.backoffMultiplier())
+	assert.Equal(t, 7, o.backoffSkipTicks())
+
+	// Out-of-range values fall back to the default rather than producing a
+	// nonsensical EWMA.
+	o.Pacing.EWMAAlpha = 1.5
+	assert.Equal(t, defaultEWMAAlpha, o.ewmaAlpha())
+}
+
+func TestWorkerPoolSize(t *testing.T) {
+	var o Originator
+	// Unset MaxConcurrentOriginations means unbounded: run all of them.
+	assert.Equal(t, 5, o.workerPoolSize(5))
+
+	o.Pacing.MaxConcurrentOriginations = 2
+	assert.Equal(t, 2, o.workerPoolSize(5))
+	// The pool never exceeds the number of interfaces actually due.
+	assert.Equal(t, 1, o.workerPoolSize(1))
+}
+
+func TestOriginationDeadline(t *testing.T) {
+	var o Originator
+	const ifID = 42
+
+	// No sample recorded yet: fall back to the default.
+	assert.Equal(t, defaultNewSenderTimeout, o.originationDeadline(ifID))
+
+	// A slow interface gets headroom above its smoothed latency.
+	o.stateFor(ifID).update(1, 10*defaultNewSenderTimeout)
+	assert.Equal(t, 20*defaultNewSenderTimeout, o.originationDeadline(ifID))
+
+	// A fast interface still gets at least the default deadline.
+	o.stateFor(ifID+1).update(1, time.Microsecond)
+	assert.Equal(t, defaultNewSenderTimeout, o.originationDeadline(ifID+1))
+}
+
+func TestRecordOriginationLatencyDemoteAndRecover(t *testing.T) {
+	o := Originator{
+		TickPeriod: time.Second,
+		Pacing: PacingConfig{
+			EWMAAlpha:         1, // no smoothing, so a single sample decides the outcome
+			BackoffMultiplier: 2,
+			BackoffSkipTicks:  2,
+		},
+	}
+	const ifID = 7
+	st := o.stateFor(ifID)
+
+	// A slow sample (latency beyond BackoffMultiplier*TickPeriod) demotes the
+	// interface: it must be skipped for BackoffSkipTicks ticks.
+	o.recordOriginationLatency(ifID, 3*time.Second)
+	assert.True(t, st.consumeSkip())
+	assert.True(t, st.consumeSkip())
+	assert.False(t, st.consumeSkip(), "interface should be due again after BackoffSkipTicks ticks")
+
+	// A fast sample recovers the interface immediately, even mid-backoff.
+	o.recordOriginationLatency(ifID, 3*time.Second)
+	assert.True(t, st.consumeSkip())
+	o.recordOriginationLatency(ifID, 10*time.Millisecond)
+	assert.False(t, st.consumeSkip(), "a fast sample should clear the remaining backoff")
+}
+
+func TestRecordOriginationLatencyNoTickPeriodNeverDemotes(t *testing.T) {
+	var o Originator
+	const ifID = 1
+
+	o.recordOriginationLatency(ifID, time.Hour)
+	assert.False(t, o.stateFor(ifID).consumeSkip(),
+		"without a TickPeriod, adaptive demotion must stay disabled")
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package control holds the control-service configuration that is loaded
+// from TOML at startup and fanned out to the individual tasks.
+package control
+
+import (
+	"context"
+
+	"github.com/scionproto/scion/control/beaconing"
+	"github.com/scionproto/scion/private/tracing"
+)
+
+// BeaconingConfig configures the control service's beaconing tasks.
+type BeaconingConfig struct {
+	// OriginationPacing configures the Originator's worker pool and adaptive
+	// per-interface deadline/backoff. See beaconing.PacingConfig.
+	OriginationPacing beaconing.PacingConfig `toml:"origination_pacing,omitempty"`
+}
+
+// Config is the control-service configuration.
+type Config struct {
+	Beaconing BeaconingConfig `toml:"beaconing,omitempty"`
+	// Tracing configures the process-wide OpenTelemetry tracer; see
+	// tracing.Config.
+	Tracing tracing.Config `toml:"tracing,omitempty"`
+}
+
+// ConfigureOriginator copies the loaded beaconing configuration into o and
+// wires up its metrics. It must be called once, before o is registered as a
+// periodic.Task.
+func (c Config) ConfigureOriginator(o *beaconing.Originator) {
+	o.Pacing = c.Beaconing.OriginationPacing
+	o.OriginationBackoff = beaconing.NewOriginationBackoffGauge()
+}
+
+// InitTracing installs the tracer configured by c.Tracing as the global
+// OpenTelemetry tracer provider, and returns the shutdown function that must
+// be called on process exit to flush pending spans.
+func (c Config) InitTracing() (func(context.Context) error, error) {
+	return tracing.InitGlobal(c.Tracing)
+}
@@ -61,12 +61,73 @@ func (s *Raw) SerializeTo(b []byte) error {
 	return nil
 }
 
+// consDirFlag is the bit of an info field's flags byte (the first byte of the
+// 8-byte info field record) that carries the ConsDir flag. See path.InfoField.
+const consDirFlag = 0x1
+
 // Reverse reverses the path such that it can be used in the reverse direction.
+//
+// This works directly on the raw representation and only parses the path
+// meta header, avoiding the full InfoField/HopField decode-reverse-encode
+// round trip of reverseDecoded. It must stay bit-for-bit equivalent to it;
+// see FuzzRawReverseMatchesDecoded.
 func (s *Raw) Reverse() (path.Path, error) {
-	// XXX(shitz): The current implementation is not the most performant, since it parses the entire
-	// path first. If this becomes a performance bottleneck, the implementation should be changed to
-	// work directly on the raw representation.
+	if s.NumINF == 0 {
+		return nil, serrors.New("empty raw path is invalid and cannot be reversed")
+	}
+
+	// Reverse the order of the info fields, flipping each one's ConsDir bit
+	// along the way so the single pass touches every info field exactly once.
+	for i, j := 0, s.NumINF-1; i <= j; i, j = i+1, j-1 {
+		iOff := MetaLen + i*path.InfoLen
+		s.Raw[iOff] ^= consDirFlag
+		if i == j {
+			break
+		}
+		jOff := MetaLen + j*path.InfoLen
+		s.Raw[jOff] ^= consDirFlag
+		swapRange(s.Raw[iOff:iOff+path.InfoLen], s.Raw[jOff:jOff+path.InfoLen])
+	}
+
+	// Reverse the order of the hop fields. Because this reverses the whole
+	// block rather than each segment independently, the hop fields of what
+	// was the last segment end up first, as a block, with their own order
+	// reversed too -- exactly mirroring the info-field reversal above.
+	hopsOff := MetaLen + s.NumINF*path.InfoLen
+	for i, j := 0, s.NumHops-1; i < j; i, j = i+1, j-1 {
+		iOff := hopsOff + i*path.HopLen
+		jOff := hopsOff + j*path.HopLen
+		swapRange(s.Raw[iOff:iOff+path.HopLen], s.Raw[jOff:jOff+path.HopLen])
+	}
+
+	// Rewrite the path meta header: reversed segment lengths and pointers
+	// into the now-reversed info/hop blocks.
+	meta := s.PathMeta
+	for i, j := 0, s.NumINF-1; i < j; i, j = i+1, j-1 {
+		meta.SegLen[i], meta.SegLen[j] = meta.SegLen[j], meta.SegLen[i]
+	}
+	meta.CurrINF = uint8(s.NumINF) - meta.CurrINF - 1
+	meta.CurrHF = uint8(s.NumHops) - meta.CurrHF - 1
+	s.PathMeta = meta
+
+	if err := s.PathMeta.SerializeTo(s.Raw); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// swapRange exchanges the contents of a and b, which must be of equal length
+// and must not overlap.
+func swapRange(a, b []byte) {
+	for i := range a {
+		a[i], b[i] = b[i], a[i]
+	}
+}
 
+// reverseDecoded reverses the path by decoding it to a Decoded, reversing
+// that, and re-serializing. It is kept as a reference implementation that
+// Reverse is checked against; see FuzzRawReverseMatchesDecoded.
+func (s *Raw) reverseDecoded() (path.Path, error) {
 	decoded, err := s.ToDecoded()
 	if err != nil {
 		return nil, err
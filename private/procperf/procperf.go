@@ -1,12 +1,15 @@
 package procperf
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/private/tracing"
 )
 
 type Type string
@@ -28,120 +31,113 @@ const (
 	maxTimeArraySize = 7
 )
 
-var file *os.File
-var once sync.Once
-var linesToWriteChan chan string
-var running = false
+// tracer is the OpenTelemetry tracer used for every ProcPerf span. Packages
+// instrument phase timings by calling GetNew, which starts a span named
+// after the given Type.
+var tracer = tracing.Tracer("github.com/scionproto/scion/private/procperf")
 
+// ProcPerf tracks the phases of processing a single beacon, segment or
+// request as an OpenTelemetry span. Each AddDuration/AddDurationT call
+// records a child event on that span instead of appending to an in-memory
+// buffer, so a ProcPerf is only useful between GetNew and Write.
 type ProcPerf struct {
-	t         Type
-	id        string
-	next_id   string
-	data      string
-	time      time.Time
-	size      int
-	durations []time.Duration
+	span Span
+	size int
+}
+
+// Span is the subset of trace.Span that ProcPerf depends on, so that tests
+// can supply a recording fake without pulling in an SDK exporter.
+type Span interface {
+	SetAttributes(...attribute.KeyValue)
+	AddEvent(name string, options ...trace.EventOption)
+	End(options ...trace.SpanEndOption)
 }
 
+// AddDurationT records the duration between t1 and t2 as a span event. It is
+// used when the two timestamps were captured around a synchronous step, e.g.
+// beacon creation or sending.
 func (pp *ProcPerf) AddDurationT(t1, t2 time.Time) {
 	if pp.size >= maxTimeArraySize {
 		log.Error("ProcPerf size exceeded", "max", maxTimeArraySize)
 		return
 	}
-	pp.durations = append(pp.durations, t2.Sub(t1))
+	pp.span.AddEvent(
+		fmt.Sprintf("step_%d", pp.size),
+		trace.WithTimestamp(t2),
+		trace.WithAttributes(attribute.Float64("duration_seconds", t2.Sub(t1).Seconds())),
+	)
 	pp.size++
 }
 
+// AddDuration records a duration, in seconds, that was measured externally
+// rather than from two time.Time values.
 func (pp *ProcPerf) AddDuration(seconds float64) {
 	if pp.size >= maxTimeArraySize {
 		log.Error("ProcPerf size exceeded", "max", maxTimeArraySize)
 		return
 	}
-	pp.durations = append(pp.durations, time.Duration(seconds*float64(time.Second)))
+	pp.span.AddEvent(
+		fmt.Sprintf("step_%d", pp.size),
+		trace.WithAttributes(attribute.Float64("duration_seconds", seconds)),
+	)
 	pp.size++
 }
 
+// SetNumBeacons attaches the number of beacons this span covers as an
+// attribute, for spans that summarize a batch rather than a single beacon.
 func (pp *ProcPerf) SetNumBeacons(num uint32) {
-	pp.data = fmt.Sprintf("%d", num)
+	pp.span.SetAttributes(attribute.Int64("num_beacons", int64(num)))
 }
 
+// SetData attaches free-form data to the span, e.g. the algorithm name for
+// an Algorithm span.
 func (pp *ProcPerf) SetData(data string) {
-	pp.data = data
+	pp.span.SetAttributes(attribute.String("data", data))
 }
 
-func (pp *ProcPerf) SetNextID(id string) {
-	pp.next_id = id
+// SetTraceparent attaches the W3C traceparent of the originating span as an
+// attribute, so that a beacon or segment can be correlated with the trace it
+// came from by ID on the exporter's side.
+func (pp *ProcPerf) SetTraceparent(traceparent string) {
+	pp.span.SetAttributes(attribute.String("traceparent", traceparent))
 }
 
-func (pp *ProcPerf) SetID(id string) {
-	pp.id = id
+// SetNextID attaches the ID of the beacon or segment this one propagates
+// into.
+func (pp *ProcPerf) SetNextID(id string) {
+	pp.span.SetAttributes(attribute.String("next_id", id))
 }
 
-func (pp *ProcPerf) string() string {
-	str := fmt.Sprintf("%s;%s;%s;%s;%s;%d;", pp.t, pp.id, pp.next_id, pp.data, pp.time.Format(time.RFC3339Nano), pp.size)
-	for i := 0; i < maxTimeArraySize; i++ {
-		if i < pp.size {
-			str += fmt.Sprintf("%f;", pp.durations[i].Seconds())
-		} else {
-			str += ";"
-		}
-	}
-	return str[:len(str)-1] + "\n"
+// SetID attaches the ID of the beacon or segment this span is about.
+func (pp *ProcPerf) SetID(id string) {
+	pp.span.SetAttributes(attribute.String("id", id))
 }
 
+// Write ends the span, making it available to the configured exporter. It
+// must be called exactly once per ProcPerf, typically via defer right after
+// GetNew.
 func (pp *ProcPerf) Write() {
-	go func() {
-		defer log.HandlePanic()
-		linesToWriteChan <- pp.string()
-	}()
-}
-
-func Init() error {
-	var err error = nil
-	once.Do(func() {
-		hostname, err := os.Hostname()
-		if err != nil {
-			log.Error("Error getting hostname", "err", err)
-		}
-		file, _ = os.OpenFile(fmt.Sprintf("procperf-%s.csv", hostname), os.O_CREATE|os.O_RDWR, 0666)
-		header := "Type;ID;Next ID;Data;Time;Size;"
-		for i := 0; i < maxTimeArraySize; i++ {
-			header += fmt.Sprintf("Duration %d;", i)
-		}
-		header = header[:len(header)-1] + "\n"
-		_, err = file.WriteString(header)
-		if err != nil {
-			log.Error("Error writing header", "err", err)
-		}
-		linesToWriteChan = make(chan string, 1000)
-		running = true
-		go func() {
-			defer log.HandlePanic()
-			run()
-		}()
-	})
-	return err
+	pp.span.SetAttributes(attribute.Int64("size", int64(pp.size)))
+	pp.span.End()
 }
 
-func run() {
-	for running {
-		line := <-linesToWriteChan
-		_, err := file.WriteString(line)
-		if err != nil {
-			log.Error("Error writing line", "err", err)
-		}
+// GetNew starts a new span of the given Type as a child of ctx's span, and
+// returns the context carrying it together with the ProcPerf used to
+// annotate it. If id is non-empty, it is attached as the "id" attribute.
+//
+// The returned context must be threaded through any further calls (e.g. to a
+// SenderFactory or Extender) that should be attributed to this span.
+func GetNew(ctx context.Context, t Type, id string) (context.Context, *ProcPerf) {
+	spanCtx, span := tracer.Start(ctx, string(t))
+	pp := &ProcPerf{span: span}
+	if id != "" {
+		pp.SetID(id)
 	}
+	return spanCtx, pp
 }
 
-func Close() {
-	running = false
-	_ = file.Close()
-}
-
-func GetNew(t Type, id string) *ProcPerf {
-	return &ProcPerf{t: t, id: id, time: time.Now(), size: 0}
-}
-
+// GetFullId combines a beacon ID with a segment ID into the identifier used
+// to correlate spans across ASes.
 func GetFullId(id string, segID uint16) string {
 	return fmt.Sprintf("%s %04x", id, segID)
 }
@@ -0,0 +1,140 @@
+// Copyright 2024 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing configures the process-wide OpenTelemetry tracer used to
+// follow requests, such as beacons, across ASes.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/zipkin"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// Collector identifies which tracing backend an exporter talks to.
+type Collector string
+
+const (
+	// CollectorNone disables tracing. GetNew still works, but spans are
+	// discarded by the no-op tracer.
+	CollectorNone Collector = "none"
+	// CollectorJaeger exports spans to the HTTP collector endpoint of a
+	// Jaeger collector, using the OpenTelemetry Jaeger exporter. OTLP is not
+	// used.
+	CollectorJaeger Collector = "jaeger"
+	// CollectorZipkin exports spans to a Zipkin collector.
+	CollectorZipkin Collector = "zipkin"
+)
+
+// Config configures the tracing subsystem. It is meant to be embedded in the
+// control-service configuration.
+type Config struct {
+	// Enabled activates tracing. If false, the global tracer provider is
+	// left as it is and none of the other fields are read.
+	Enabled bool `toml:"enabled,omitempty"`
+	// Collector selects the exporter backend. Defaults to CollectorNone.
+	Collector Collector `toml:"collector,omitempty"`
+	// Endpoint is the collector endpoint, e.g. http://localhost:9411/api/v2/spans
+	// for Zipkin or http://localhost:14268/api/traces for Jaeger.
+	Endpoint string `toml:"endpoint,omitempty"`
+	// ServiceName identifies this process in the exported spans. If empty,
+	// the caller of InitGlobal is expected to supply a default.
+	ServiceName string `toml:"service_name,omitempty"`
+	// SamplerRate is the fraction of traces that are sampled, in [0, 1].
+	// A value of 0 disables sampling; 1 samples every trace.
+	SamplerRate float64 `toml:"sampler_rate,omitempty"`
+}
+
+// InitGlobal builds a TracerProvider from cfg, installs it as the global
+// OpenTelemetry tracer provider and propagator, and returns a shutdown
+// function that must be called to flush pending spans on process exit.
+//
+// If cfg.Enabled is false, or cfg.Collector is CollectorNone or empty, the
+// global tracer provider is left as it is (OpenTelemetry's no-op default
+// unless one was set before) and the returned shutdown function is a no-op.
+func InitGlobal(cfg Config) (func(context.Context) error, error) {
+	if !cfg.Enabled || cfg.Collector == CollectorNone || cfg.Collector == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(cfg)
+	if err != nil {
+		return nil, serrors.Wrap("creating trace exporter", err, "collector", cfg.Collector)
+	}
+
+	res, err := resource.New(context.Background(),
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName)),
+	)
+	if err != nil {
+		return nil, serrors.Wrap("building trace resource", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(cfg.SamplerRate)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(cfg Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Collector {
+	case CollectorZipkin:
+		return zipkin.New(cfg.Endpoint)
+	case CollectorJaeger:
+		return jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(cfg.Endpoint)))
+	default:
+		return nil, serrors.New("unknown tracing collector", "collector", cfg.Collector)
+	}
+}
+
+// Tracer returns the named tracer from the global tracer provider. Packages
+// that create spans should call this once and keep the result, rather than
+// calling otel.Tracer repeatedly.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// InjectString encodes the span context carried by ctx into a traceparent
+// header value, as defined by the W3C Trace Context specification. The
+// beacon originator records the result as a span attribute only; it is not
+// carried in the beacon itself.
+func InjectString(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// ExtractString returns a context that continues the trace encoded in
+// traceparent, as produced by InjectString. If traceparent is empty or
+// malformed, ctx is returned unchanged.
+func ExtractString(ctx context.Context, traceparent string) context.Context {
+	if traceparent == "" {
+		return ctx
+	}
+	carrier := propagation.MapCarrier{"traceparent": traceparent}
+	return propagation.TraceContext{}.Extract(ctx, carrier)
+}
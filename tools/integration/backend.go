@@ -0,0 +1,157 @@
+// Copyright 2024 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// Backend names accepted by -topology-backend.
+const (
+	BackendNative       = "native"
+	BackendKathara      = "kathara"
+	BackendContainerlab = "containerlab"
+	BackendCompose      = "compose"
+)
+
+// topologyBackendEnvPrefix is the common prefix under which every backend
+// looks up the path to its generated lab/topology file, e.g.
+// SCION_TOPOLOGY_KATHARA or SCION_TOPOLOGY_CONTAINERLAB.
+const topologyBackendEnvPrefix = "SCION_TOPOLOGY_"
+
+var (
+	// TopologyBackend selects how integration tests reach the AS containers
+	// or nodes that make up the test topology.
+	TopologyBackend = flag.String("topology-backend", BackendNative,
+		fmt.Sprintf("Topology backend to run integration tests against (%s, %s, %s, %s)",
+			BackendNative, BackendKathara, BackendContainerlab, BackendCompose))
+
+	// backends holds the non-native backends, registered by their init().
+	backends = map[string]Backend{}
+)
+
+func registerBackend(name string, b Backend) {
+	backends[name] = b
+}
+
+// Backend adapts a binaryIntegration so that its server and client commands
+// run inside the AS containers or nodes of a generated test topology, rather
+// than directly on the host.
+type Backend interface {
+	// WrapServerCmd rewrites bi.cmd/bi.serverArgs in place so that running
+	// them executes the server inside dst's container or node.
+	WrapServerCmd(bi *binaryIntegration, dst *snet.UDPAddr)
+	// WrapClientCmd rewrites bi.cmd/bi.clientArgs in place so that running
+	// them executes the client inside src's container or node.
+	WrapClientCmd(bi *binaryIntegration, src, dst *snet.UDPAddr)
+	// EndhostID returns the backend-specific identifier (container ID, node
+	// label, compose service name, ...) of the endhost owning a.
+	EndhostID(a *snet.UDPAddr) string
+}
+
+// backendIntegration wraps a binaryIntegration so that it executes inside a
+// pluggable topology backend, selected via -topology-backend.
+type backendIntegration struct {
+	*binaryIntegration
+	backend Backend
+}
+
+// katharize is kept for callers still built against the pre-pluggable-backend
+// API; it is equivalent to selectBackend.
+//
+// Deprecated: use selectBackend.
+func katharize(bi *binaryIntegration) Integration {
+	return selectBackend(bi)
+}
+
+// resolveBackendName returns the name of the topology backend selected via
+// -topology-backend, falling back to BackendKathara if the legacy -k flag is
+// set and no other backend was explicitly selected. Both selectBackend and
+// the free EndhostID function must agree on this resolution, so it lives
+// here once rather than being reimplemented at each call site.
+func resolveBackendName() string {
+	name := *TopologyBackend
+	if *Kathara && name == BackendNative {
+		name = BackendKathara
+	}
+	return name
+}
+
+// selectBackend adapts bi to run under the topology backend selected via
+// -topology-backend, the legacy -k flag, or falls back to it unwrapped
+// (BackendNative) if neither selects a backend.
+func selectBackend(bi *binaryIntegration) Integration {
+	name := resolveBackendName()
+	if name == BackendNative {
+		return bi
+	}
+	b, ok := backends[name]
+	if !ok {
+		panic(fmt.Sprintf("unknown topology backend %q", name))
+	}
+	return &backendIntegration{binaryIntegration: bi, backend: b}
+}
+
+// StartServer starts a server and blocks until the ReadySignal is received on Stdout.
+func (gi *backendIntegration) StartServer(ctx context.Context, dst *snet.UDPAddr) (Waiter, error) {
+	bi := *gi.binaryIntegration
+	gi.backend.WrapServerCmd(&bi, dst)
+	return bi.StartServer(ctx, dst)
+}
+
+// StartClient starts a client.
+func (gi *backendIntegration) StartClient(ctx context.Context,
+	src, dst *snet.UDPAddr) (*BinaryWaiter, error) {
+	bi := *gi.binaryIntegration
+	gi.backend.WrapClientCmd(&bi, src, dst)
+	return bi.StartClient(ctx, src, dst)
+}
+
+// EndhostID returns the ID of the endhost container or node for a, under the
+// currently selected topology backend. With BackendNative, a is reached
+// directly and there is no separate endhost identifier, so a's own IA is used.
+//
+// Callers that already hold a backendIntegration (i.e. that went through
+// selectBackend) should use its EndhostID method instead, so the backend
+// resolved once at selection time is reused rather than re-resolved here.
+func EndhostID(a *snet.UDPAddr) string {
+	b, ok := backends[resolveBackendName()]
+	if !ok {
+		return a.IA.String()
+	}
+	return b.EndhostID(a)
+}
+
+// EndhostID returns the ID of the endhost container or node for a, under the
+// backend gi was selected with, without re-resolving -topology-backend.
+func (gi *backendIntegration) EndhostID(a *snet.UDPAddr) string {
+	return gi.backend.EndhostID(a)
+}
+
+// backendLabFile returns the path to the lab/topology file generated for the
+// named backend, read from SCION_TOPOLOGY_<NAME>, falling back to the
+// default <name>_lab file generated alongside the test artifacts.
+func backendLabFile(name string) string {
+	if path, ok := os.LookupEnv(topologyBackendEnvPrefix + strings.ToUpper(name)); ok {
+		return path
+	}
+	return GenFile(name + "_lab")
+}
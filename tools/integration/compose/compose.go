@@ -0,0 +1,85 @@
+// Copyright 2024 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compose generates a Docker Compose file from the existing SCION
+// topology files, for use with the "compose" integration test backend.
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/addr"
+)
+
+// Service is one Compose service derived from a SCION AS.
+type Service struct {
+	// Name is the Compose service name, using the same "sd<ia>" convention
+	// the compose backend's EndhostID resolves back to.
+	Name  string
+	Image string
+}
+
+// ServiceName derives the Compose service name for a file-separated IA
+// string (as returned by addr.FormatIA(ia, addr.WithFileSeparator())),
+// matching the "sd<ia>" convention the compose integration backend's
+// EndhostID uses.
+func ServiceName(fileSeparatedIA string) string {
+	return fmt.Sprintf("sd%s", strings.Replace(fileSeparatedIA, "-", "_", -1))
+}
+
+// topoFile is the subset of an AS's topology.json this package cares about.
+type topoFile struct {
+	IsdAs string `json:"isd_as"`
+}
+
+// Generate reads every topology.json listed in topoFiles and renders a
+// minimal Docker Compose file with one service per AS, each running image.
+// The service name is derived from each topology file's own isd_as field,
+// not from the file's path, so the emitted config always matches what the
+// AS itself claims to be. It is the counterpart to the compose integration
+// backend, which expects this file at the path
+// backendLabFile(BackendCompose)/SCION_TOPOLOGY_COMPOSE resolves to.
+func Generate(topoFiles []string, image string) ([]byte, error) {
+	var services []Service
+	for _, path := range topoFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading topology file %s: %w", path, err)
+		}
+		var topo topoFile
+		if err := json.Unmarshal(data, &topo); err != nil {
+			return nil, fmt.Errorf("parsing topology file %s: %w", path, err)
+		}
+		ia, err := addr.ParseIA(topo.IsdAs)
+		if err != nil {
+			return nil, fmt.Errorf("topology file %s has invalid isd_as %q: %w", path, topo.IsdAs, err)
+		}
+		fileSeparatedIA := addr.FormatIA(ia, addr.WithFileSeparator())
+		services = append(services, Service{Name: ServiceName(fileSeparatedIA), Image: image})
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+
+	var b strings.Builder
+	fmt.Fprintln(&b, "services:")
+	for _, svc := range services {
+		fmt.Fprintf(&b, "  %s:\n", svc.Name)
+		fmt.Fprintf(&b, "    image: %s\n", svc.Image)
+		fmt.Fprintln(&b, "    network_mode: host")
+	}
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command compose_gen generates the Docker Compose file the "compose"
+// integration test backend reads, from the topology.json of every AS under
+// -gen-dir. Run it ahead of `go test -topology-backend=compose ...`, writing
+// -out to the path backendLabFile("compose")/SCION_TOPOLOGY_COMPOSE expects.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/scionproto/scion/tools/integration/compose"
+)
+
+func main() {
+	genDir := flag.String("gen-dir", "gen",
+		"Directory containing one ASxxx subdirectory per AS, each holding a topology.json")
+	image := flag.String("image", "", "Docker image to run for every AS service")
+	out := flag.String("out", "", "Path to write the generated Compose file to")
+	flag.Parse()
+
+	if *image == "" || *out == "" {
+		fmt.Fprintln(os.Stderr, "-image and -out are required")
+		os.Exit(2)
+	}
+
+	topoFiles, err := filepath.Glob(filepath.Join(*genDir, "AS*", "topology.json"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "globbing topology files:", err)
+		os.Exit(1)
+	}
+	if len(topoFiles) == 0 {
+		fmt.Fprintf(os.Stderr, "no topology.json files found under %s\n", *genDir)
+		os.Exit(1)
+	}
+
+	data, err := compose.Generate(topoFiles, *image)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "generating compose file:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "writing compose file:", err)
+		os.Exit(1)
+	}
+}
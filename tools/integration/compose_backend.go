@@ -0,0 +1,69 @@
+// Copyright 2024 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/tools/integration/compose"
+)
+
+const composeCmd = "docker"
+
+func init() {
+	registerBackend(BackendCompose, composeBackend{})
+}
+
+var _ Backend = composeBackend{}
+
+// composeBackend runs integration tests against a Docker Compose topology,
+// invoking `docker compose -f <compose-file> exec <svc>` to reach each
+// endhost.
+type composeBackend struct{}
+
+func (composeBackend) composeArgs() []string {
+	return []string{"compose", "-f", backendLabFile(BackendCompose), "exec"}
+}
+
+// WrapServerCmd rewrites bi to run the server inside dst's Compose service.
+func (b composeBackend) WrapServerCmd(bi *binaryIntegration, dst *snet.UDPAddr) {
+	temp := append([]string{"env", fmt.Sprintf("%s=1", GoIntegrationEnv), "bash -c \"" + bi.cmd}, bi.serverArgs...)
+	bi.serverArgs = append(b.composeArgs(),
+		[]string{b.EndhostID(dst), strings.Join(temp, " ") + "\""}...)
+	bi.cmd = composeCmd
+	log.Debug(fmt.Sprintf("Starting server for %s in compose",
+		addr.FormatIA(dst.IA, addr.WithFileSeparator())),
+	)
+}
+
+// WrapClientCmd rewrites bi to run the client inside src's Compose service.
+func (b composeBackend) WrapClientCmd(bi *binaryIntegration, src, dst *snet.UDPAddr) {
+	temp := append([]string{"env", fmt.Sprintf("%s=1", GoIntegrationEnv), "bash -c \"" + bi.cmd}, bi.clientArgs...)
+	bi.clientArgs = append(b.composeArgs(),
+		[]string{b.EndhostID(src), strings.Join(temp, " ") + "\""}...)
+	bi.cmd = composeCmd
+	log.Debug(fmt.Sprintf("Starting client for %s in compose",
+		addr.FormatIA(src.IA, addr.WithFileSeparator())),
+	)
+}
+
+// EndhostID returns the Compose service name of the endhost owning a.
+func (composeBackend) EndhostID(a *snet.UDPAddr) string {
+	return compose.ServiceName(addr.FormatIA(a.IA, addr.WithFileSeparator()))
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package containerlab generates a Containerlab topology file from the
+// existing SCION topology files, for use with the "containerlab" integration
+// test backend.
+package containerlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/addr"
+)
+
+// Node is one Containerlab node derived from a SCION AS.
+type Node struct {
+	// Name is the clab-node-name label, using the same "sd<ia>" convention
+	// the containerlab backend's EndhostID resolves back to.
+	Name  string
+	Kind  string
+	Image string
+}
+
+// NodeName derives the clab-node-name for a file-separated IA string (as
+// returned by addr.FormatIA(ia, addr.WithFileSeparator())), matching the
+// "sd<ia>" convention the containerlab integration backend's EndhostID uses.
+func NodeName(fileSeparatedIA string) string {
+	return fmt.Sprintf("sd%s", strings.Replace(fileSeparatedIA, "-", "_", -1))
+}
+
+// topoFile is the subset of an AS's topology.json this package cares about.
+type topoFile struct {
+	IsdAs string `json:"isd_as"`
+}
+
+// Generate reads every topology.json listed in topoFiles and renders a
+// minimal Containerlab topology file with one "linux" node per AS, each
+// running image. The node name is derived from each topology file's own
+// isd_as field, not from the file's path, so the emitted config always
+// matches what the AS itself claims to be. It is the counterpart to the
+// containerlab integration backend, which expects this file at the path
+// backendLabFile(BackendContainerlab)/SCION_TOPOLOGY_CONTAINERLAB resolves
+// to.
+func Generate(topoFiles []string, image string) ([]byte, error) {
+	var nodes []Node
+	for _, path := range topoFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading topology file %s: %w", path, err)
+		}
+		var topo topoFile
+		if err := json.Unmarshal(data, &topo); err != nil {
+			return nil, fmt.Errorf("parsing topology file %s: %w", path, err)
+		}
+		ia, err := addr.ParseIA(topo.IsdAs)
+		if err != nil {
+			return nil, fmt.Errorf("topology file %s has invalid isd_as %q: %w", path, topo.IsdAs, err)
+		}
+		fileSeparatedIA := addr.FormatIA(ia, addr.WithFileSeparator())
+		nodes = append(nodes, Node{Name: NodeName(fileSeparatedIA), Kind: "linux", Image: image})
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	var b strings.Builder
+	fmt.Fprintln(&b, "name: scion")
+	fmt.Fprintln(&b, "topology:")
+	fmt.Fprintln(&b, "  nodes:")
+	for _, n := range nodes {
+		fmt.Fprintf(&b, "    %s:\n", n.Name)
+		fmt.Fprintf(&b, "      kind: %s\n", n.Kind)
+		fmt.Fprintf(&b, "      image: %s\n", n.Image)
+	}
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/tools/integration/containerlab"
+)
+
+const containerlabCmd = "containerlab"
+
+func init() {
+	registerBackend(BackendContainerlab, containerlabBackend{})
+}
+
+var _ Backend = containerlabBackend{}
+
+// containerlabBackend runs integration tests inside a Containerlab topology,
+// invoking `containerlab exec -t <topo> --label clab-node-name=<name> --cmd
+// <cmd>` to reach each endhost.
+type containerlabBackend struct{}
+
+func (containerlabBackend) containerlabArgs() []string {
+	return []string{"exec", "-t", backendLabFile(BackendContainerlab)}
+}
+
+// WrapServerCmd rewrites bi to run the server inside dst's Containerlab node.
+func (b containerlabBackend) WrapServerCmd(bi *binaryIntegration, dst *snet.UDPAddr) {
+	temp := append([]string{"env", fmt.Sprintf("%s=1", GoIntegrationEnv), "bash -c \"" + bi.cmd}, bi.serverArgs...)
+	bi.serverArgs = append(b.containerlabArgs(),
+		"--label", fmt.Sprintf("clab-node-name=%s", b.EndhostID(dst)),
+		"--cmd", strings.Join(temp, " ")+"\"",
+	)
+	bi.cmd = containerlabCmd
+	log.Debug(fmt.Sprintf("Starting server for %s in containerlab",
+		addr.FormatIA(dst.IA, addr.WithFileSeparator())),
+	)
+}
+
+// WrapClientCmd rewrites bi to run the client inside src's Containerlab node.
+func (b containerlabBackend) WrapClientCmd(bi *binaryIntegration, src, dst *snet.UDPAddr) {
+	temp := append([]string{"env", fmt.Sprintf("%s=1", GoIntegrationEnv), "bash -c \"" + bi.cmd}, bi.clientArgs...)
+	bi.clientArgs = append(b.containerlabArgs(),
+		"--label", fmt.Sprintf("clab-node-name=%s", b.EndhostID(src)),
+		"--cmd", strings.Join(temp, " ")+"\"",
+	)
+	bi.cmd = containerlabCmd
+	log.Debug(fmt.Sprintf("Starting client for %s in containerlab",
+		addr.FormatIA(src.IA, addr.WithFileSeparator())),
+	)
+}
+
+// EndhostID returns the clab-node-name of the endhost owning a.
+func (containerlabBackend) EndhostID(a *snet.UDPAddr) string {
+	return containerlab.NodeName(addr.FormatIA(a.IA, addr.WithFileSeparator()))
+}
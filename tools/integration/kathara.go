@@ -15,7 +15,6 @@
 package integration
 
 import (
-	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -38,50 +37,41 @@ var (
 var katharaArgs []string
 
 func initKatharaArgs() {
-	katharaArgs = []string{"exec", "-d", GenFile("kathara_lab")}
+	katharaArgs = []string{"exec", "-d", backendLabFile(BackendKathara)}
 }
 
-var _ Integration = (*katharaIntegration)(nil)
-
-type katharaIntegration struct {
-	*binaryIntegration
+func init() {
+	registerBackend(BackendKathara, katharaBackend{})
 }
 
-func katharize(bi *binaryIntegration) Integration {
-	if *Kathara {
-		return &katharaIntegration{
-			binaryIntegration: bi,
-		}
-	}
-	return bi
-}
+var _ Backend = katharaBackend{}
+
+// katharaBackend runs integration tests inside a Kathara lab, invoking
+// `kathara exec -d <lab> <container>` to reach each endhost.
+type katharaBackend struct{}
 
-// StartServer starts a server and blocks until the ReadySignal is received on Stdout.
-func (ki *katharaIntegration) StartServer(ctx context.Context, dst *snet.UDPAddr) (Waiter, error) {
-	bi := *ki.binaryIntegration
+// WrapServerCmd rewrites bi to run the server inside dst's Kathara container.
+func (b katharaBackend) WrapServerCmd(bi *binaryIntegration, dst *snet.UDPAddr) {
 	temp := append([]string{"env", fmt.Sprintf("%s=1", GoIntegrationEnv), "bash -c \"" + bi.cmd}, bi.serverArgs...)
-	bi.serverArgs = append(katharaArgs, []string{EndhostID(dst), strings.Join(temp, " ") + "\""}...)
+	bi.serverArgs = append(katharaArgs, []string{b.EndhostID(dst), strings.Join(temp, " ") + "\""}...)
 	bi.cmd = katharaCmd
 	log.Debug(fmt.Sprintf("Starting server for %s in kathara",
 		addr.FormatIA(dst.IA, addr.WithFileSeparator())),
 	)
-	return bi.StartServer(ctx, dst)
 }
 
-func (ki *katharaIntegration) StartClient(ctx context.Context,
-	src, dst *snet.UDPAddr) (*BinaryWaiter, error) {
-	bi := *ki.binaryIntegration
+// WrapClientCmd rewrites bi to run the client inside src's Kathara container.
+func (b katharaBackend) WrapClientCmd(bi *binaryIntegration, src, dst *snet.UDPAddr) {
 	temp := append([]string{"env", fmt.Sprintf("%s=1", GoIntegrationEnv), "bash -c \"" + bi.cmd}, bi.clientArgs...)
-	bi.clientArgs = append(katharaArgs, []string{EndhostID(src), strings.Join(temp, " ") + "\""}...)
+	bi.clientArgs = append(katharaArgs, []string{b.EndhostID(src), strings.Join(temp, " ") + "\""}...)
 	bi.cmd = katharaCmd
 	log.Debug(fmt.Sprintf("Starting client for %s in kathara",
 		addr.FormatIA(src.IA, addr.WithFileSeparator())),
 	)
-	return bi.StartClient(ctx, src, dst)
 }
 
 // EndhostID returns the ID of the endhost container.
-func EndhostID(a *snet.UDPAddr) string {
+func (katharaBackend) EndhostID(a *snet.UDPAddr) string {
 	ia := addr.FormatIA(a.IA, addr.WithFileSeparator())
 	envID, ok := os.LookupEnv(fmt.Sprintf("sd%s", strings.Replace(ia, "-", "_", -1)))
 	if !ok {